@@ -0,0 +1,102 @@
+package goes
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CheckpointStore persists the last event version processed by a
+// Projection, so it can resume where it left off after a restart.
+type CheckpointStore interface {
+	// Load returns the last saved version for name, or -1 if none has
+	// been saved yet.
+	Load(name string) (int, error)
+
+	// Save persists version as the last processed version for name.
+	Save(name string, version int) error
+}
+
+// InMemoryCheckpointStore is a CheckpointStore backed by a plain map,
+// useful in tests where checkpoints do not need to survive the process.
+type InMemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]int
+}
+
+// NewInMemoryCheckpointStore returns an empty InMemoryCheckpointStore.
+func NewInMemoryCheckpointStore() *InMemoryCheckpointStore {
+	return &InMemoryCheckpointStore{checkpoints: make(map[string]int)}
+}
+
+// Load implements CheckpointStore.
+func (s *InMemoryCheckpointStore) Load(name string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.checkpoints[name]
+	if !ok {
+		return -1, nil
+	}
+	return v, nil
+}
+
+// Save implements CheckpointStore.
+func (s *InMemoryCheckpointStore) Save(name string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[name] = version
+	return nil
+}
+
+// EventStoreCheckpointStore is a CheckpointStore that persists each
+// projection's checkpoint as the latest event on its own
+// "checkpoint-{name}" stream.
+type EventStoreCheckpointStore struct {
+	client Client
+}
+
+// NewEventStoreCheckpointStore returns a CheckpointStore that saves
+// checkpoints to the eventstore reached through client.
+func NewEventStoreCheckpointStore(client Client) *EventStoreCheckpointStore {
+	return &EventStoreCheckpointStore{client: client}
+}
+
+type checkpointEvent struct {
+	Version int
+}
+
+func checkpointStreamName(name string) string {
+	return fmt.Sprintf("checkpoint-%s", name)
+}
+
+// Load implements CheckpointStore, returning the version recorded by the
+// most recent checkpoint event on the projection's checkpoint stream, or
+// -1 if none has been saved yet.
+func (s *EventStoreCheckpointStore) Load(name string) (int, error) {
+	reader := s.client.NewStreamReader(checkpointStreamName(name))
+	last := -1
+	for reader.Next() {
+		if err := reader.Err(); err != nil {
+			if _, ok := err.(*NoMoreEventsError); ok {
+				break
+			}
+			if _, ok := err.(*NotFoundError); ok {
+				break
+			}
+			return -1, err
+		}
+		var cp checkpointEvent
+		if err := reader.Scan(&cp, nil); err != nil {
+			return -1, err
+		}
+		last = cp.Version
+	}
+	return last, nil
+}
+
+// Save implements CheckpointStore by appending a checkpoint event
+// recording version to the projection's checkpoint stream.
+func (s *EventStoreCheckpointStore) Save(name string, version int) error {
+	writer := s.client.NewStreamWriter(checkpointStreamName(name))
+	event := ToEventData("", "Checkpoint", checkpointEvent{Version: version}, nil)
+	return writer.Append(nil, event)
+}
@@ -0,0 +1,99 @@
+package goes
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Client is the interface through which an application interacts with an
+// eventstore server. A Client is safe for concurrent use.
+type Client interface {
+	// NewStreamReader creates a StreamReader for the named stream.
+	NewStreamReader(streamName string) StreamReader
+
+	// NewStreamWriter creates a StreamWriter for the named stream.
+	NewStreamWriter(streamName string) StreamWriter
+
+	// SetBasicAuth sets the credentials used for subsequent requests.
+	SetBasicAuth(username, password string)
+
+	// SubscribeToStream returns a CatchUpSubscription that replays the
+	// named stream from fromVersion and then transitions to a live tail
+	// of new events as they are written.
+	SubscribeToStream(streamName string, fromVersion int) (*CatchUpSubscription, error)
+
+	// CreatePersistentSubscription creates a persistent subscription
+	// group on stream.
+	CreatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error
+
+	// UpdatePersistentSubscription updates an existing persistent
+	// subscription group's settings.
+	UpdatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error
+
+	// DeletePersistentSubscription deletes a persistent subscription
+	// group.
+	DeletePersistentSubscription(stream, group string) error
+
+	// ConnectToPersistentSubscription connects to an existing persistent
+	// subscription group as a competing consumer.
+	ConnectToPersistentSubscription(stream, group string, bufferSize int) (*PersistentSubscription, error)
+}
+
+// client is the default Client implementation, talking to the eventstore
+// HTTP (Atom over HTTP) API.
+type client struct {
+	http     *http.Client
+	baseURL  *url.URL
+	username string
+	password string
+}
+
+// NewClient creates a new Client for the eventstore instance at baseURL.
+// If httpClient is nil, http.DefaultClient is used.
+func NewClient(httpClient *http.Client, baseURL string) (Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("goes: invalid base url: %s", err)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{http: httpClient, baseURL: u}, nil
+}
+
+func (c *client) SetBasicAuth(username, password string) {
+	c.username = username
+	c.password = password
+}
+
+func (c *client) NewStreamReader(streamName string) StreamReader {
+	return newStreamReader(c, streamName)
+}
+
+func (c *client) NewStreamWriter(streamName string) StreamWriter {
+	return newStreamWriter(c, streamName)
+}
+
+// newRequest builds an http.Request against the client's base URL,
+// applying basic auth credentials when they have been set.
+func (c *client) newRequest(method, ref string, body []byte) (*http.Request, error) {
+	u, err := c.baseURL.Parse(ref)
+	if err != nil {
+		return nil, err
+	}
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, u.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	return req, nil
+}
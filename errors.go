@@ -0,0 +1,53 @@
+package goes
+
+import "fmt"
+
+// ConcurrencyError is returned when an Append call is made with an
+// expected version that does not match the actual version of the stream.
+type ConcurrencyError struct {
+	ExpectedVersion int
+	ActualVersion   int
+}
+
+func (e *ConcurrencyError) Error() string {
+	return fmt.Sprintf("goes: concurrency error: expected version %d does not match actual version %d", e.ExpectedVersion, e.ActualVersion)
+}
+
+// NotFoundError is returned when the stream, event or resource requested
+// does not exist on the eventstore server.
+type NotFoundError struct {
+	URL string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("goes: not found: %s", e.URL)
+}
+
+// UnauthorizedError is returned when the credentials provided to the
+// client are not authorised to perform the requested operation.
+type UnauthorizedError struct {
+	URL string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("goes: unauthorized: %s", e.URL)
+}
+
+// NoMoreEventsError is returned by a StreamReader when there are no
+// more events available at the requested position.
+type NoMoreEventsError struct{}
+
+func (e *NoMoreEventsError) Error() string {
+	return "goes: no more events"
+}
+
+// TemporarilyUnavailableError is returned when the eventstore server
+// responds with a StatusServiceUnavailable, typically while it is still
+// starting up or is temporarily overloaded.
+type TemporarilyUnavailableError struct {
+	URL string
+}
+
+func (e *TemporarilyUnavailableError) Error() string {
+	return fmt.Sprintf("goes: temporarily unavailable: %s", e.URL)
+}
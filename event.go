@@ -0,0 +1,59 @@
+package goes
+
+import (
+	"crypto/rand"
+	"fmt"
+	"reflect"
+)
+
+// Event represents an event to be written to, or that has been read from,
+// an eventstore stream.
+type Event struct {
+	EventID   string
+	EventType string
+	Data      interface{}
+	MetaData  interface{}
+}
+
+// EventResponse wraps an Event together with the information returned by
+// the server about its position in the stream.
+type EventResponse struct {
+	Event       *Event
+	EventNumber int
+}
+
+// ToEventData builds an *Event ready to be appended to a stream.
+//
+// If eventID is empty a new one is generated with NewUUID. If eventType
+// is empty it is reflected from the type name of data.
+func ToEventData(eventID string, eventType string, data interface{}, meta interface{}) *Event {
+	if eventID == "" {
+		eventID = NewUUID()
+	}
+	if eventType == "" {
+		t := reflect.TypeOf(data)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		eventType = t.Name()
+	}
+	return &Event{
+		EventID:   eventID,
+		EventType: eventType,
+		Data:      data,
+		MetaData:  meta,
+	}
+}
+
+// NewUUID generates a random (version 4) UUID string.
+func NewUUID() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read on a correctly sized buffer never returns a short
+	// read, so the only possible error is a broken entropy source.
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
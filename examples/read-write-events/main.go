@@ -144,7 +144,7 @@ func readEvents(client goes.Client, streamName string) {
 				log.Fatal(reader.Err())
 			}
 
-			log.Printf("\n Event %d returned %#v\n Meta returned %#v\n\n", reader.EventResponse().Event.EventNumber, fooEvent, fooMeta)
+			log.Printf("\n Event %d returned %#v\n Meta returned %#v\n\n", reader.EventResponse().EventNumber, fooEvent, fooMeta)
 		}
 	}
 }
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// withBasicAuth attaches basic-auth-style credentials to the outgoing
+// gRPC metadata, mirroring the "authorization" header the Atom-over-HTTP
+// transport sends via SetBasicAuth.
+func withBasicAuth(ctx context.Context, username, password string) context.Context {
+	token := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+token)
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// Credentials holds the username and password sent with every call, via
+// the "authorization" gRPC metadata header.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client is a goes transport backed by EventStoreDB's gRPC API. It is
+// safe for concurrent use.
+type Client struct {
+	pool  *connPool
+	creds Credentials
+}
+
+// NewClient dials the given cluster endpoints and returns a Client.
+// Endpoints are tried in order as gossip seeds: the first one that
+// answers gossip identifies the current leader, and subsequent requests
+// are routed there directly (see cluster.go). An endpoint that does not
+// answer gossip is not dialled directly, so NewClient fails if none of
+// them does.
+func NewClient(endpoints []string, creds Credentials) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("goesgrpc: at least one endpoint is required")
+	}
+	pool, err := newConnPool(endpoints)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{pool: pool, creds: creds}, nil
+}
+
+// NewStreamReader returns a StreamReader for streamName that satisfies
+// goes.StreamReader.
+func (c *Client) NewStreamReader(streamName string) goes.StreamReader {
+	return newStreamReader(c, streamName)
+}
+
+// NewStreamWriter returns a StreamWriter for streamName that satisfies
+// goes.StreamWriter.
+func (c *Client) NewStreamWriter(streamName string) goes.StreamWriter {
+	return newStreamWriter(c, streamName)
+}
+
+// Delete soft-deletes streamName, scavengeable later by the server. If
+// hard is true the stream is permanently tombstoned and can never be
+// recreated; see Tombstone for a dedicated method that does the same.
+func (c *Client) Delete(streamName string, expectedVersion *int, hard bool) error {
+	return mapStatus(c.withRetry(func(sc streamsClient) error {
+		return sc.Delete(c.ctx(), streamName, expectedVersion, hard)
+	}))
+}
+
+// Tombstone permanently deletes streamName; unlike Delete(hard=false),
+// a tombstoned stream can never be recreated or written to again.
+func (c *Client) Tombstone(streamName string, expectedVersion *int) error {
+	return mapStatus(c.withRetry(func(sc streamsClient) error {
+		return sc.Tombstone(c.ctx(), streamName, expectedVersion)
+	}))
+}
+
+func (c *Client) ctx() context.Context {
+	ctx := context.Background()
+	if c.creds.Username != "" {
+		ctx = withBasicAuth(ctx, c.creds.Username, c.creds.Password)
+	}
+	return ctx
+}
+
+// streamsClient is the slice of the generated streams.Streams client
+// stub that this package depends on.
+type streamsClient interface {
+	Read(ctx context.Context, stream string, fromVersion int, backwards bool, count int) (eventStream, error)
+	Append(ctx context.Context, stream string, expectedVersion *int, events []*goes.Event) error
+	Delete(ctx context.Context, stream string, expectedVersion *int, hard bool) error
+	Tombstone(ctx context.Context, stream string, expectedVersion *int) error
+}
+
+// eventStream is the receive side of a streaming Read call.
+type eventStream interface {
+	Recv() (*goes.EventResponse, error)
+}
+
+func (c *Client) streams() streamsClient {
+	return c.pool.current().streams
+}
+
+// withRetry calls fn against the pool's current connection, and if fn
+// fails because that node has become unreachable, re-gossips for the new
+// leader (see connPool.refresh) and retries fn once more against it.
+func (c *Client) withRetry(fn func(streamsClient) error) error {
+	err := fn(c.streams())
+	if err != nil && c.pool.refresh(err) {
+		err = fn(c.streams())
+	}
+	return err
+}
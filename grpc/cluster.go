@@ -0,0 +1,170 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// conn is a single gRPC connection to a cluster node together with the
+// streams stub bound to it.
+type conn struct {
+	endpoint string
+	cc       *grpc.ClientConn
+	streams  streamsClient
+}
+
+// connPool tracks the cluster's endpoints and the connection to whichever
+// node last reported itself as leader, refreshing that via gossip when a
+// call fails against the current node.
+type connPool struct {
+	seeds []string
+
+	mu   sync.RWMutex
+	curr *conn
+}
+
+func newConnPool(endpoints []string) (*connPool, error) {
+	p := &connPool{seeds: endpoints}
+	if err := p.rediscover(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *connPool) current() *conn {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.curr
+}
+
+// refresh re-gossips to find the current leader if err indicates the
+// node behind the current connection has become unreachable, reporting
+// whether it found a new one, so the caller can retry its request
+// against current() once.
+func (p *connPool) refresh(err error) bool {
+	if !isUnavailable(err) {
+		return false
+	}
+	return p.rediscover() == nil
+}
+
+// rediscover gossips with each seed endpoint in turn until one reports
+// cluster member information, then dials the member it identifies as
+// leader. EventStoreDB nodes expose gossip over HTTP at
+// /gossip?format=json; here we only need the subset of that response
+// identifying the leader's gRPC endpoint.
+func (p *connPool) rediscover() error {
+	var lastErr error
+	for _, seed := range p.seeds {
+		leader, err := gossipLeader(seed)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		c, err := dial(leader)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		p.mu.Lock()
+		p.curr = c
+		p.mu.Unlock()
+		return nil
+	}
+	return fmt.Errorf("goesgrpc: could not discover a leader from any seed: %w", lastErr)
+}
+
+// isUnavailable reports whether err indicates the node a call was made
+// against is unreachable, whether that's a raw gRPC status or one
+// already translated by mapStatus.
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*goes.TemporarilyUnavailableError); ok {
+		return true
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unavailable
+}
+
+// gossipMember is the subset of a single entry in EventStoreDB's
+// /gossip?format=json response this package needs.
+type gossipMember struct {
+	State            string `json:"state"`
+	IsAlive          bool   `json:"isAlive"`
+	ExternalHTTPIP   string `json:"externalHttpIp"`
+	ExternalHTTPPort int    `json:"externalHttpPort"`
+}
+
+type gossipResponse struct {
+	Members []gossipMember `json:"members"`
+}
+
+// gossipLeader queries a single node's gossip endpoint and returns the
+// gRPC address of the member currently acting as leader. EventStoreDB
+// 20+ serves gRPC over the same port as its external HTTP API, so the
+// leader's ExternalHTTPIP/Port is also its gRPC endpoint.
+func gossipLeader(endpoint string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s/gossip?format=json", endpoint), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("goesgrpc: gossip %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("goesgrpc: gossip %s: unexpected status %d", endpoint, resp.StatusCode)
+	}
+
+	var gr gossipResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gr); err != nil {
+		return "", fmt.Errorf("goesgrpc: gossip %s: %w", endpoint, err)
+	}
+
+	for _, m := range gr.Members {
+		if m.IsAlive && m.State == "Leader" {
+			return fmt.Sprintf("%s:%d", m.ExternalHTTPIP, m.ExternalHTTPPort), nil
+		}
+	}
+	return "", fmt.Errorf("goesgrpc: gossip %s: no alive Leader member in response", endpoint)
+}
+
+func dial(endpoint string) (*conn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cc, err := grpc.DialContext(ctx, endpoint,
+		grpc.WithBlock(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("goesgrpc: dial %s: %w", endpoint, err)
+	}
+
+	streams, err := newStreamsStub(cc)
+	if err != nil {
+		cc.Close()
+		return nil, err
+	}
+	return &conn{
+		endpoint: endpoint,
+		cc:       cc,
+		streams:  streams,
+	}, nil
+}
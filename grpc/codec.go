@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// scanEvent deserialises the raw JSON payload of an event delivered over
+// gRPC into the given targets, mirroring goes.StreamReader.Scan on the
+// Atom-over-HTTP transport so callers can use either transport
+// interchangeably.
+func scanEvent(resp *goes.EventResponse, eventOut interface{}, metaOut interface{}) error {
+	if resp == nil || resp.Event == nil {
+		return fmt.Errorf("goesgrpc: Scan called before a successful call to Next")
+	}
+	if eventOut != nil {
+		if data, ok := resp.Event.Data.([]byte); ok && len(data) > 0 {
+			if err := json.Unmarshal(data, eventOut); err != nil {
+				return err
+			}
+		}
+	}
+	if metaOut != nil {
+		if meta, ok := resp.Event.MetaData.([]byte); ok && len(meta) > 0 {
+			if err := json.Unmarshal(meta, metaOut); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
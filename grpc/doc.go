@@ -0,0 +1,13 @@
+// Package grpc is a transport for EventStoreDB 20+ clusters that speaks
+// the server's native gRPC protocol instead of the Atom-over-HTTP
+// protocol used by the root goes package. It satisfies the same
+// goes.StreamReader and goes.StreamWriter contracts, so code written
+// against goes.NewClient keeps working after swapping in NewClient from
+// this package.
+//
+// No protoc-generated stubs are used: the streams.Streams messages this
+// package needs are encoded by hand against the protobuf wire format
+// (wireproto.go, streamsmsg.go), and streamsClient (client.go) is the
+// narrow interface over that service which the rest of the package
+// calls, implemented in stub.go.
+package grpc
@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"github.com/jetbasrawi/goes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mapStatus translates a gRPC status error returned by the streams
+// service into the goes error types used by the Atom-over-HTTP
+// transport, so callers can type-switch on goes errors regardless of
+// which transport they are using.
+func mapStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.FailedPrecondition:
+		return &goes.ConcurrencyError{}
+	case codes.NotFound:
+		return &goes.NotFoundError{}
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return &goes.UnauthorizedError{}
+	case codes.Unavailable:
+		return &goes.TemporarilyUnavailableError{}
+	default:
+		return err
+	}
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"github.com/jetbasrawi/goes"
+)
+
+// ReadAllEvents reads count events from the $all stream starting at
+// position, in the given direction. $all reads are only available over
+// the gRPC protocol, so this method is exposed on Client rather than on
+// the shared goes.Client interface.
+func (c *Client) ReadAllEvents(position AllPosition, backwards bool, count int) ([]*goes.EventResponse, error) {
+	var recv eventStream
+	err := c.withRetry(func(sc streamsClient) error {
+		var rerr error
+		recv, rerr = sc.Read(c.ctx(), "$all", int(position.Commit), backwards, count)
+		return rerr
+	})
+	if err != nil {
+		return nil, mapStatus(err)
+	}
+
+	var events []*goes.EventResponse
+	for {
+		resp, err := recv.Recv()
+		if err != nil {
+			break
+		}
+		events = append(events, resp)
+	}
+	return events, nil
+}
+
+// AllPosition identifies a position in the $all stream.
+type AllPosition struct {
+	Commit  uint64
+	Prepare uint64
+}
+
+// ConnectToPersistentSubscription connects to a persistent subscription
+// group on the gRPC protocol, returning delivered events on a channel.
+// Each event is acked automatically once it has been sent on Events();
+// no per-event ack or nack is exposed. This method exists because the
+// gRPC protocol additionally allows subscribing to the $all persistent
+// subscription, which has no HTTP equivalent.
+func (c *Client) ConnectToPersistentSubscription(stream, group string, bufferSize int) (*PersistentSubscriptionStream, error) {
+	return newPersistentSubscriptionStream(c, stream, group, bufferSize)
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import "github.com/jetbasrawi/goes"
+
+// PersistentSubscriptionStream delivers events from a persistent
+// subscription connected to over gRPC. Each delivered event is
+// automatically acked once it has been sent on Events(); bufferSize
+// bounds how many unacked events the server will have outstanding for
+// this connection at once.
+type PersistentSubscriptionStream struct {
+	client *Client
+	stream string
+	group  string
+
+	events chan *goes.EventResponse
+	errs   chan error
+	done   chan struct{}
+}
+
+func newPersistentSubscriptionStream(c *Client, stream, group string, bufferSize int) (*PersistentSubscriptionStream, error) {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	s := &PersistentSubscriptionStream{
+		client: c,
+		stream: stream,
+		group:  group,
+		events: make(chan *goes.EventResponse, bufferSize),
+		errs:   make(chan error),
+		done:   make(chan struct{}),
+	}
+	go s.run(bufferSize)
+	return s, nil
+}
+
+// Events returns the channel on which delivered events are sent.
+func (s *PersistentSubscriptionStream) Events() <-chan *goes.EventResponse {
+	return s.events
+}
+
+// Errors returns the channel on which a fatal error reading the
+// subscription is sent; the subscription ends after one is delivered.
+func (s *PersistentSubscriptionStream) Errors() <-chan error {
+	return s.errs
+}
+
+// Close ends the subscription.
+func (s *PersistentSubscriptionStream) Close() error {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	return nil
+}
+
+func (s *PersistentSubscriptionStream) run(bufferSize int) {
+	defer close(s.events)
+	defer close(s.errs)
+
+	cs, err := newPersistentReadStream(s.client.ctx(), s.client.pool.current().cc, s.stream, s.group, bufferSize)
+	if err != nil {
+		s.sendErr(mapStatus(err))
+		return
+	}
+
+	var subscriptionID string
+	for {
+		resp := &wireBytes{}
+		if err := cs.RecvMsg(resp); err != nil {
+			s.sendErr(mapStatus(err))
+			return
+		}
+
+		confirmationID, ev, err := unmarshalPersistentReadResp(resp.b)
+		if err != nil {
+			s.sendErr(err)
+			return
+		}
+		if confirmationID != "" {
+			subscriptionID = confirmationID
+			continue
+		}
+		if ev == nil {
+			continue
+		}
+
+		select {
+		case s.events <- &goes.EventResponse{
+			Event: &goes.Event{
+				EventID:   ev.id,
+				EventType: ev.eventType,
+				Data:      ev.data,
+				MetaData:  ev.metaData,
+			},
+			EventNumber: int(ev.streamRevision),
+		}:
+		case <-s.done:
+			return
+		}
+
+		if err := cs.SendMsg(&wireBytes{b: marshalPersistentAck(subscriptionID, ev.id)}); err != nil {
+			s.sendErr(mapStatus(err))
+			return
+		}
+	}
+}
+
+func (s *PersistentSubscriptionStream) sendErr(err error) {
+	select {
+	case s.errs <- err:
+	case <-s.done:
+	}
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// The message shapes below follow persistent_subscriptions.proto's
+// ReadReq/ReadResp (package event_store.client.persistent_subscriptions);
+// see wireproto.go for why these are hand-encoded rather than generated.
+
+// marshalPersistentReadOptions builds the first message of a Read
+// request stream: ReadReq{ options: Options{ stream_identifier: {
+// stream_identifier }, group_name, buffer_size } }.
+func marshalPersistentReadOptions(streamName, group string, bufferSize int) []byte {
+	streamOpt := appendMessageField(nil, 1, marshalStreamIdentifier(streamName))
+
+	opts := appendMessageField(nil, 1, streamOpt)
+	opts = appendStringField(opts, 3, group)
+	opts = appendUint64Field(opts, 4, uint64(bufferSize))
+
+	return appendMessageField(nil, 1, opts)
+}
+
+// marshalPersistentAck builds a subsequent message of the Read request
+// stream acknowledging a single delivered event: ReadReq{ ack: Ack{ id:
+// subscriptionID, ids: [eventID] } }.
+func marshalPersistentAck(subscriptionID, eventID string) []byte {
+	ack := appendBytesField(nil, 1, []byte(subscriptionID))
+	ack = appendMessageField(ack, 2, marshalUUIDString(eventID))
+	return appendMessageField(nil, 2, ack)
+}
+
+// unmarshalPersistentReadResp decodes one ReadResp message. It returns a
+// non-empty confirmationID for a SubscriptionConfirmation, a non-nil ev
+// for a ReadEvent, or both zero for a variant this package doesn't use.
+func unmarshalPersistentReadResp(b []byte) (confirmationID string, ev *readEvent, err error) {
+	for len(b) > 0 {
+		num, typ, val, n, ferr := consumeField(b)
+		if ferr != nil {
+			return "", nil, ferr
+		}
+		if typ == protowire.BytesType {
+			switch num {
+			case 1: // event
+				ev, err = unmarshalReadEvent(val)
+				return "", ev, err
+			case 2: // subscription_confirmation
+				confirmationID, err = unmarshalSubscriptionConfirmation(val)
+				return confirmationID, nil, err
+			}
+		}
+		b = b[n:]
+	}
+	return "", nil, nil
+}
+
+func unmarshalSubscriptionConfirmation(b []byte) (string, error) {
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return "", err
+		}
+		if num == 1 && typ == protowire.BytesType {
+			return string(val), nil
+		}
+		b = b[n:]
+	}
+	return "", nil
+}
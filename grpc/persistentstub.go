@@ -0,0 +1,22 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const persistentSubscriptionsService = "/event_store.client.persistent_subscriptions.PersistentSubscriptions/"
+
+// newPersistentReadStream opens the bidirectional Read RPC and sends the
+// initial subscribe options message.
+func newPersistentReadStream(ctx context.Context, cc *grpc.ClientConn, streamName, group string, bufferSize int) (grpc.ClientStream, error) {
+	cs, err := cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Read", ServerStreams: true, ClientStreams: true}, persistentSubscriptionsService+"Read", grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.SendMsg(&wireBytes{b: marshalPersistentReadOptions(streamName, group, bufferSize)}); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
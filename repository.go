@@ -0,0 +1,31 @@
+package goes
+
+import "fmt"
+
+// Repository is a source of truth for a set of event streams, decoupled
+// from any particular wire protocol. It underlies NewInMemoryClient, and
+// can be implemented against other backing stores for testing or
+// replay.
+type Repository interface {
+	// Replay returns the events recorded on stream at or after
+	// fromVersion, in the order they were appended.
+	Replay(stream string, fromVersion int) ([]*Event, error)
+
+	// Append adds events to the end of stream. If expectedVersion is
+	// non-nil, the append is conditional on the stream being at that
+	// version, returning a *ConcurrencyError if it is not.
+	Append(stream string, expectedVersion *int, events ...*Event) error
+}
+
+// TruncationError is returned by a Repository when a caller asks to
+// replay from a version that has fallen outside of a bounded backing
+// store's retention window.
+type TruncationError struct {
+	Stream        string
+	RequestedFrom int
+	LowestValid   int
+}
+
+func (e *TruncationError) Error() string {
+	return fmt.Sprintf("goes: stream %q has been truncated; requested version %d is before the lowest retained version %d", e.Stream, e.RequestedFrom, e.LowestValid)
+}
@@ -0,0 +1,103 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryPolicy controls how a retryingClient retries a failed Append.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts made after the
+	// first one fails. A value of 0 disables retrying.
+	MaxRetries int
+	// Backoff is the delay between attempts.
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy is used by a Projection when no RetryPolicy has
+// been set explicitly.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 5, Backoff: 200 * time.Millisecond}
+
+// retryingClient wraps a Client so that every StreamWriter it creates
+// retries an Append that fails with a *ConcurrencyError, re-reading the
+// stream's current version between attempts.
+type retryingClient struct {
+	Client
+	policy RetryPolicy
+}
+
+func (c *retryingClient) NewStreamWriter(streamName string) StreamWriter {
+	return &retryingStreamWriter{
+		client:     c.Client,
+		streamName: streamName,
+		inner:      c.Client.NewStreamWriter(streamName),
+		policy:     c.policy,
+	}
+}
+
+type retryingStreamWriter struct {
+	client     Client
+	streamName string
+	inner      StreamWriter
+	policy     RetryPolicy
+}
+
+func (w *retryingStreamWriter) Append(expectedVersion *int, events ...*Event) error {
+	var err error
+	for attempt := 0; attempt <= w.policy.MaxRetries; attempt++ {
+		err = w.inner.Append(expectedVersion, events...)
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*ConcurrencyError); !ok {
+			return err
+		}
+		if attempt < w.policy.MaxRetries {
+			time.Sleep(w.policy.Backoff)
+			// expectedVersion has permanently diverged from the
+			// stream's actual version; re-read it so the next
+			// attempt targets the version that's actually there
+			// instead of repeating the same failing append.
+			if version, verr := w.currentVersion(); verr == nil {
+				expectedVersion = &version
+			}
+		}
+	}
+	return err
+}
+
+// currentVersion returns the EventNumber of the most recently written
+// event on the stream.
+func (w *retryingStreamWriter) currentVersion() (int, error) {
+	reader := w.client.NewStreamReader(w.streamName)
+	version := -1
+	for reader.Next() {
+		if err := reader.Err(); err != nil {
+			if _, ok := err.(*NoMoreEventsError); ok {
+				break
+			}
+			return 0, err
+		}
+		version = reader.EventResponse().EventNumber
+	}
+	if version < 0 {
+		return 0, fmt.Errorf("goes: stream %q has no events", w.streamName)
+	}
+	return version, nil
+}
+
+type clientContextKey struct{}
+
+// ClientFromContext returns the Client a Projection handler should use
+// to write events, i.e. the one wrapped with automatic retry-on-
+// ConcurrencyError by Projection.Run. It returns nil if ctx was not
+// derived from one passed to a handler by Run.
+func ClientFromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(clientContextKey{}).(Client)
+	return c
+}
+
+func contextWithClient(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, clientContextKey{}, c)
+}
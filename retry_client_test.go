@@ -0,0 +1,193 @@
+package goes
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeStreamWriter returns the next error from errs on each Append call,
+// returning nil once exhausted, and records the expectedVersion
+// it was called with.
+type fakeStreamWriter struct {
+	errs     []error
+	calls    int
+	versions []*int
+}
+
+func (w *fakeStreamWriter) Append(expectedVersion *int, events ...*Event) error {
+	w.versions = append(w.versions, expectedVersion)
+	var err error
+	if w.calls < len(w.errs) {
+		err = w.errs[w.calls]
+	}
+	w.calls++
+	return err
+}
+
+// fakeStreamReader yields a fixed list of EventResponses and then a
+// NoMoreEventsError, or a configured terminal error.
+type fakeStreamReader struct {
+	events []*EventResponse
+	i      int
+	endErr error
+}
+
+func (r *fakeStreamReader) Next() bool { return true }
+
+func (r *fakeStreamReader) Err() error {
+	if r.i < len(r.events) {
+		return nil
+	}
+	if r.endErr != nil {
+		return r.endErr
+	}
+	return &NoMoreEventsError{}
+}
+
+func (r *fakeStreamReader) Scan(eventOut interface{}, metaOut interface{}) error { return nil }
+
+func (r *fakeStreamReader) EventResponse() *EventResponse {
+	resp := r.events[r.i]
+	r.i++
+	return resp
+}
+
+func (r *fakeStreamReader) NextVersion(version int) {}
+
+// fakeClient is a minimal Client whose NewStreamReader/NewStreamWriter
+// return pre-configured fakes; the other methods are unused by the code
+// under test here.
+type fakeClient struct {
+	writer *fakeStreamWriter
+	reader *fakeStreamReader
+}
+
+func (c *fakeClient) NewStreamReader(streamName string) StreamReader { return c.reader }
+func (c *fakeClient) NewStreamWriter(streamName string) StreamWriter { return c.writer }
+func (c *fakeClient) SetBasicAuth(username, password string)         {}
+func (c *fakeClient) SubscribeToStream(streamName string, fromVersion int) (*CatchUpSubscription, error) {
+	return nil, errors.New("not implemented")
+}
+func (c *fakeClient) CreatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	return errors.New("not implemented")
+}
+func (c *fakeClient) UpdatePersistentSubscription(stream, group string, settings PersistentSubscriptionSettings) error {
+	return errors.New("not implemented")
+}
+func (c *fakeClient) DeletePersistentSubscription(stream, group string) error {
+	return errors.New("not implemented")
+}
+func (c *fakeClient) ConnectToPersistentSubscription(stream, group string, bufferSize int) (*PersistentSubscription, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRetryingStreamWriterAppend(t *testing.T) {
+	t.Run("succeeds on the first attempt without retrying", func(t *testing.T) {
+		writer := &fakeStreamWriter{}
+		client := &fakeClient{writer: writer}
+		w := &retryingStreamWriter{client: client, streamName: "s1", inner: writer, policy: RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}}
+
+		if err := w.Append(nil, &Event{EventID: "a"}); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if writer.calls != 1 {
+			t.Fatalf("calls = %d, want 1", writer.calls)
+		}
+	})
+
+	t.Run("non-concurrency errors are not retried", func(t *testing.T) {
+		boom := errors.New("boom")
+		writer := &fakeStreamWriter{errs: []error{boom}}
+		client := &fakeClient{writer: writer}
+		w := &retryingStreamWriter{client: client, streamName: "s1", inner: writer, policy: RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}}
+
+		if err := w.Append(nil, &Event{EventID: "a"}); err != boom {
+			t.Fatalf("Append returned %v, want %v", err, boom)
+		}
+		if writer.calls != 1 {
+			t.Fatalf("calls = %d, want 1", writer.calls)
+		}
+	})
+
+	t.Run("retries a ConcurrencyError and re-reads the current version", func(t *testing.T) {
+		writer := &fakeStreamWriter{errs: []error{
+			&ConcurrencyError{ExpectedVersion: 0, ActualVersion: 2},
+			nil,
+		}}
+		reader := &fakeStreamReader{events: []*EventResponse{
+			{Event: &Event{EventID: "a"}, EventNumber: 0},
+			{Event: &Event{EventID: "b"}, EventNumber: 1},
+			{Event: &Event{EventID: "c"}, EventNumber: 2},
+		}}
+		client := &fakeClient{writer: writer, reader: reader}
+		w := &retryingStreamWriter{client: client, streamName: "s1", inner: writer, policy: RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}}
+
+		expected := 0
+		if err := w.Append(&expected, &Event{EventID: "d"}); err != nil {
+			t.Fatalf("Append returned error: %v", err)
+		}
+		if writer.calls != 2 {
+			t.Fatalf("calls = %d, want 2", writer.calls)
+		}
+		if v := writer.versions[1]; v == nil || *v != 2 {
+			t.Fatalf("second attempt's expectedVersion = %v, want 2", v)
+		}
+	})
+
+	t.Run("gives up after MaxRetries and returns the last error", func(t *testing.T) {
+		cerr := &ConcurrencyError{ExpectedVersion: 0, ActualVersion: 1}
+		writer := &fakeStreamWriter{errs: []error{cerr, cerr, cerr}}
+		reader := &fakeStreamReader{endErr: errors.New("stream unavailable")}
+		client := &fakeClient{writer: writer, reader: reader}
+		w := &retryingStreamWriter{client: client, streamName: "s1", inner: writer, policy: RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}}
+
+		expected := 0
+		if err := w.Append(&expected, &Event{EventID: "a"}); err != cerr {
+			t.Fatalf("Append returned %v, want %v", err, cerr)
+		}
+		if writer.calls != 3 {
+			t.Fatalf("calls = %d, want 3", writer.calls)
+		}
+	})
+}
+
+func TestRetryingStreamWriterCurrentVersion(t *testing.T) {
+	t.Run("returns the EventNumber of the last event", func(t *testing.T) {
+		reader := &fakeStreamReader{events: []*EventResponse{
+			{Event: &Event{EventID: "a"}, EventNumber: 0},
+			{Event: &Event{EventID: "b"}, EventNumber: 1},
+		}}
+		client := &fakeClient{reader: reader}
+		w := &retryingStreamWriter{client: client, streamName: "s1"}
+
+		version, err := w.currentVersion()
+		if err != nil {
+			t.Fatalf("currentVersion returned error: %v", err)
+		}
+		if version != 1 {
+			t.Fatalf("version = %d, want 1", version)
+		}
+	})
+
+	t.Run("errors when the stream has no events", func(t *testing.T) {
+		reader := &fakeStreamReader{}
+		client := &fakeClient{reader: reader}
+		w := &retryingStreamWriter{client: client, streamName: "empty"}
+
+		if _, err := w.currentVersion(); err == nil {
+			t.Fatal("expected an error for a stream with no events")
+		}
+	})
+
+	t.Run("propagates a non-NoMoreEvents reader error", func(t *testing.T) {
+		boom := errors.New("boom")
+		reader := &fakeStreamReader{endErr: boom}
+		client := &fakeClient{reader: reader}
+		w := &retryingStreamWriter{client: client, streamName: "s1"}
+
+		if _, err := w.currentVersion(); err != boom {
+			t.Fatalf("currentVersion returned %v, want %v", err, boom)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package goes
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sseEvent is a single decoded Server-Sent Event.
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  string
+	Retry time.Duration
+}
+
+// sseDecoder decodes a Server-Sent Events stream per the WHATWG spec
+// fields this client cares about: id, event, data and retry. It does not
+// depend on any external SSE library.
+type sseDecoder struct {
+	scanner *bufio.Scanner
+}
+
+func newSSEDecoder(r io.Reader) *sseDecoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &sseDecoder{scanner: scanner}
+}
+
+// Next reads and returns the next event from the stream. It returns
+// io.EOF when the underlying reader is exhausted.
+func (d *sseDecoder) Next() (*sseEvent, error) {
+	ev := &sseEvent{}
+	var data []string
+	sawField := false
+
+	for d.scanner.Scan() {
+		line := d.scanner.Text()
+
+		if line == "" {
+			if !sawField {
+				// Blank lines before any field are ignored, per spec.
+				continue
+			}
+			ev.Data = strings.Join(data, "\n")
+			return ev, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			// Comment line, ignored.
+			continue
+		}
+
+		field, value := splitSSEField(line)
+		switch field {
+		case "id":
+			ev.ID = value
+			sawField = true
+		case "event":
+			ev.Event = value
+			sawField = true
+		case "data":
+			data = append(data, value)
+			sawField = true
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				ev.Retry = time.Duration(ms) * time.Millisecond
+			}
+			sawField = true
+		}
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if sawField {
+		ev.Data = strings.Join(data, "\n")
+		return ev, nil
+	}
+	return nil, io.EOF
+}
+
+// splitSSEField splits a raw SSE line into its field name and value,
+// trimming a single leading space from the value as required by the spec.
+func splitSSEField(line string) (field, value string) {
+	i := strings.IndexByte(line, ':')
+	if i == -1 {
+		return line, ""
+	}
+	field = line[:i]
+	value = line[i+1:]
+	if strings.HasPrefix(value, " ") {
+		value = value[1:]
+	}
+	return field, value
+}
@@ -0,0 +1,112 @@
+package goes
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSEDecoderNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []sseEvent
+	}{
+		{
+			name:  "single event with id and data",
+			input: "id: 1\nevent: Foo\ndata: {\"a\":1}\n\n",
+			want: []sseEvent{
+				{ID: "1", Event: "Foo", Data: `{"a":1}`},
+			},
+		},
+		{
+			name:  "multi-line data is joined with newlines",
+			input: "data: line one\ndata: line two\n\n",
+			want: []sseEvent{
+				{Data: "line one\nline two"},
+			},
+		},
+		{
+			name:  "comment lines are ignored",
+			input: ": this is a comment\ndata: hello\n\n",
+			want: []sseEvent{
+				{Data: "hello"},
+			},
+		},
+		{
+			name:  "retry sets a duration in milliseconds",
+			input: "retry: 5000\ndata: hi\n\n",
+			want: []sseEvent{
+				{Data: "hi", Retry: 5000 * time.Millisecond},
+			},
+		},
+		{
+			name:  "non-numeric retry is ignored",
+			input: "retry: soon\ndata: hi\n\n",
+			want: []sseEvent{
+				{Data: "hi"},
+			},
+		},
+		{
+			name:  "leading blank lines before any field are ignored",
+			input: "\n\ndata: hi\n\n",
+			want: []sseEvent{
+				{Data: "hi"},
+			},
+		},
+		{
+			name:  "a trailing event with no terminating blank line is still returned",
+			input: "data: hi",
+			want: []sseEvent{
+				{Data: "hi"},
+			},
+		},
+		{
+			name:  "multiple events in one stream",
+			input: "data: one\n\ndata: two\n\n",
+			want: []sseEvent{
+				{Data: "one"},
+				{Data: "two"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newSSEDecoder(strings.NewReader(tt.input))
+			for i, want := range tt.want {
+				got, err := d.Next()
+				if err != nil {
+					t.Fatalf("event %d: Next() returned error %v", i, err)
+				}
+				if *got != want {
+					t.Fatalf("event %d: got %+v, want %+v", i, *got, want)
+				}
+			}
+			if _, err := d.Next(); err != io.EOF {
+				t.Fatalf("expected io.EOF after the last event, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSplitSSEField(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantField string
+		wantValue string
+	}{
+		{"data: hello", "data", "hello"},
+		{"data:hello", "data", "hello"},
+		{"data:  two spaces", "data", " two spaces"},
+		{"data", "data", ""},
+	}
+
+	for _, tt := range tests {
+		field, value := splitSSEField(tt.line)
+		if field != tt.wantField || value != tt.wantValue {
+			t.Errorf("splitSSEField(%q) = (%q, %q), want (%q, %q)", tt.line, field, value, tt.wantField, tt.wantValue)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package goes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// StreamReader reads events from a single stream, one event at a time,
+// starting from version 0 unless NextVersion is called.
+type StreamReader interface {
+	// Next advances the reader to the next event. It always returns true;
+	// callers must inspect Err() to find out whether the advance
+	// succeeded.
+	Next() bool
+
+	// Err returns the error, if any, that occurred on the last call to
+	// Next.
+	Err() error
+
+	// Scan deserialises the current event's data and metadata into the
+	// given targets.
+	Scan(eventOut interface{}, metaOut interface{}) error
+
+	// EventResponse returns the event retrieved by the last call to Next.
+	EventResponse() *EventResponse
+
+	// NextVersion sets the version of the stream that the next call to
+	// Next will retrieve.
+	NextVersion(version int)
+}
+
+type streamReader struct {
+	client     *client
+	streamName string
+	version    int
+	current    *EventResponse
+	rawEvent   *rawEvent
+	err        error
+}
+
+type rawEvent struct {
+	EventID   string          `json:"eventId"`
+	EventType string          `json:"eventType"`
+	Data      json.RawMessage `json:"data"`
+	MetaData  json.RawMessage `json:"metaData"`
+}
+
+func newStreamReader(c *client, streamName string) StreamReader {
+	return &streamReader{client: c, streamName: streamName}
+}
+
+func (r *streamReader) NextVersion(version int) {
+	r.version = version
+}
+
+func (r *streamReader) Next() bool {
+	ref := fmt.Sprintf("/streams/%s/%d", r.streamName, r.version)
+	req, err := r.client.newRequest("GET", ref, nil)
+	if err != nil {
+		r.err = err
+		return true
+	}
+	req.Header.Set("Accept", "application/vnd.eventstore.atom+json")
+
+	resp, err := r.client.http.Do(req)
+	if err != nil {
+		r.err = err
+		return true
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// fall through to decode below
+	case http.StatusNotFound:
+		r.err = &NotFoundError{URL: req.URL.String()}
+		return true
+	case http.StatusUnauthorized:
+		r.err = &UnauthorizedError{URL: req.URL.String()}
+		return true
+	case http.StatusServiceUnavailable:
+		r.err = &TemporarilyUnavailableError{URL: req.URL.String()}
+		return true
+	case http.StatusGone:
+		r.err = &NoMoreEventsError{}
+		return true
+	default:
+		r.err = fmt.Errorf("goes: unexpected status %d reading %s", resp.StatusCode, req.URL.String())
+		return true
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		r.err = err
+		return true
+	}
+
+	var raw rawEvent
+	if err := json.Unmarshal(body, &raw); err != nil {
+		r.err = err
+		return true
+	}
+
+	r.rawEvent = &raw
+	r.current = &EventResponse{
+		Event: &Event{
+			EventID:   raw.EventID,
+			EventType: raw.EventType,
+			Data:      raw.Data,
+			MetaData:  raw.MetaData,
+		},
+		EventNumber: r.version,
+	}
+	r.version++
+	r.err = nil
+	return true
+}
+
+func (r *streamReader) Err() error {
+	return r.err
+}
+
+func (r *streamReader) EventResponse() *EventResponse {
+	return r.current
+}
+
+func (r *streamReader) Scan(eventOut interface{}, metaOut interface{}) error {
+	if r.rawEvent == nil {
+		return fmt.Errorf("goes: Scan called before a successful call to Next")
+	}
+	if eventOut != nil && len(r.rawEvent.Data) > 0 {
+		if err := json.Unmarshal(r.rawEvent.Data, eventOut); err != nil {
+			return err
+		}
+	}
+	if metaOut != nil && len(r.rawEvent.MetaData) > 0 {
+		if err := json.Unmarshal(r.rawEvent.MetaData, metaOut); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package goes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+// StreamWriter appends events to a single stream.
+type StreamWriter interface {
+	// Append writes events to the stream. If expectedVersion is non-nil,
+	// the write is conditional on the stream being at that version,
+	// returning a *ConcurrencyError if it is not. If expectedVersion is
+	// nil the events are appended regardless of the current version.
+	Append(expectedVersion *int, events ...*Event) error
+}
+
+type streamWriter struct {
+	client     *client
+	streamName string
+}
+
+func newStreamWriter(c *client, streamName string) StreamWriter {
+	return &streamWriter{client: c, streamName: streamName}
+}
+
+type writeEvent struct {
+	EventID   string      `json:"eventId"`
+	EventType string      `json:"eventType"`
+	Data      interface{} `json:"data"`
+	MetaData  interface{} `json:"metaData,omitempty"`
+}
+
+func (w *streamWriter) Append(expectedVersion *int, events ...*Event) error {
+	payload := make([]writeEvent, len(events))
+	for i, e := range events {
+		payload[i] = writeEvent{
+			EventID:   e.EventID,
+			EventType: e.EventType,
+			Data:      e.Data,
+			MetaData:  e.MetaData,
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ref := fmt.Sprintf("/streams/%s", w.streamName)
+	req, err := w.client.newRequest("POST", ref, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.eventstore.events+json")
+	if expectedVersion != nil {
+		req.Header.Set("ES-ExpectedVersion", strconv.Itoa(*expectedVersion))
+	}
+
+	resp, err := w.client.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return nil
+	case http.StatusBadRequest:
+		actual, convErr := strconv.Atoi(resp.Header.Get("ES-CurrentVersion"))
+		if convErr != nil {
+			actual = -1
+		}
+		expected := -1
+		if expectedVersion != nil {
+			expected = *expectedVersion
+		}
+		return &ConcurrencyError{ExpectedVersion: expected, ActualVersion: actual}
+	case http.StatusNotFound:
+		return &NotFoundError{URL: req.URL.String()}
+	case http.StatusUnauthorized:
+		return &UnauthorizedError{URL: req.URL.String()}
+	case http.StatusServiceUnavailable:
+		return &TemporarilyUnavailableError{URL: req.URL.String()}
+	default:
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("goes: unexpected status %d writing to %s: %s", resp.StatusCode, req.URL.String(), respBody)
+	}
+}
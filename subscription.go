@@ -0,0 +1,257 @@
+package goes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	minSubscriptionBackoff = 1 * time.Second
+	maxSubscriptionBackoff = 30 * time.Second
+)
+
+// CatchUpSubscription replays a stream from a given version and then
+// keeps delivering new events as they are written, reconnecting
+// automatically if the underlying connection drops.
+//
+// Events and errors are delivered on separate channels; both are closed
+// after Close is called.
+type CatchUpSubscription struct {
+	client     *client
+	streamName string
+
+	events chan *EventResponse
+	errs   chan error
+	done   chan struct{}
+}
+
+// Events returns the channel on which delivered events are sent.
+func (s *CatchUpSubscription) Events() <-chan *EventResponse {
+	return s.events
+}
+
+// Errors returns the channel on which non-fatal errors encountered while
+// reading or reconnecting are sent.
+func (s *CatchUpSubscription) Errors() <-chan error {
+	return s.errs
+}
+
+// Close stops the subscription and releases its connection.
+func (s *CatchUpSubscription) Close() error {
+	select {
+	case <-s.done:
+		// already closed
+	default:
+		close(s.done)
+	}
+	return nil
+}
+
+// SubscribeToStream drains historical events from fromVersion using a
+// StreamReader and then transitions into a live SSE tail of the stream,
+// resuming from the last processed EventNumber whenever the connection
+// drops.
+func (c *client) SubscribeToStream(streamName string, fromVersion int) (*CatchUpSubscription, error) {
+	sub := &CatchUpSubscription{
+		client:     c,
+		streamName: streamName,
+		events:     make(chan *EventResponse),
+		errs:       make(chan error),
+		done:       make(chan struct{}),
+	}
+	go sub.run(fromVersion)
+	return sub, nil
+}
+
+func (s *CatchUpSubscription) run(fromVersion int) {
+	defer close(s.events)
+	defer close(s.errs)
+
+	lastEventNumber := s.catchUp(fromVersion)
+	if lastEventNumber == nil {
+		return
+	}
+
+	backoff := minSubscriptionBackoff
+	for {
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+
+		retry, err := s.tailOnce(*lastEventNumber, lastEventNumber)
+		if err == nil {
+			// tailOnce only returns nil once the subscription is closed.
+			return
+		}
+
+		select {
+		case s.errs <- err:
+		case <-s.done:
+			return
+		}
+
+		if retry > 0 {
+			backoff = retry
+		} else if backoff < maxSubscriptionBackoff {
+			backoff *= 2
+			if backoff > maxSubscriptionBackoff {
+				backoff = maxSubscriptionBackoff
+			}
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// catchUp replays events from fromVersion up to the current head of the
+// stream using a plain StreamReader, forwarding each to the events
+// channel. It returns the EventNumber to resume the live tail from, or
+// nil if the subscription was closed while catching up.
+func (s *CatchUpSubscription) catchUp(fromVersion int) *int {
+	reader := s.client.NewStreamReader(s.streamName)
+	reader.NextVersion(fromVersion)
+	lastEventNumber := fromVersion - 1
+	backoff := minSubscriptionBackoff
+
+	for reader.Next() {
+		select {
+		case <-s.done:
+			return nil
+		default:
+		}
+
+		if err := reader.Err(); err != nil {
+			if _, ok := err.(*NoMoreEventsError); ok {
+				break
+			}
+			select {
+			case s.errs <- err:
+			case <-s.done:
+				return nil
+			}
+			select {
+			case <-time.After(backoff):
+			case <-s.done:
+				return nil
+			}
+			if backoff < maxSubscriptionBackoff {
+				backoff *= 2
+				if backoff > maxSubscriptionBackoff {
+					backoff = maxSubscriptionBackoff
+				}
+			}
+			continue
+		}
+		backoff = minSubscriptionBackoff
+
+		resp := reader.EventResponse()
+		select {
+		case s.events <- resp:
+			lastEventNumber = resp.EventNumber
+		case <-s.done:
+			return nil
+		}
+	}
+
+	n := lastEventNumber
+	return &n
+}
+
+// tailOnce opens a single SSE connection to the stream starting after
+// lastEventNumber and delivers events until the connection drops or the
+// subscription is closed. It returns the server-provided retry interval
+// (zero if none was sent) and the error that ended the connection.
+func (s *CatchUpSubscription) tailOnce(lastEventNumber int, resumeFrom *int) (time.Duration, error) {
+	ref := fmt.Sprintf("/streams/%s", s.streamName)
+	req, err := s.client.newRequest("GET", ref, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Last-Event-ID", fmt.Sprintf("%d", lastEventNumber))
+
+	// Cancel the request when the subscription is closed, so Close
+	// releases a connection that is blocked waiting for data.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	req = req.WithContext(ctx)
+
+	resp, err := s.client.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// fall through to decode below
+	case http.StatusServiceUnavailable:
+		return 0, &TemporarilyUnavailableError{URL: req.URL.String()}
+	case http.StatusUnauthorized:
+		return 0, &UnauthorizedError{URL: req.URL.String()}
+	default:
+		return 0, fmt.Errorf("goes: unexpected status %d subscribing to %s", resp.StatusCode, req.URL.String())
+	}
+
+	decoder := newSSEDecoder(resp.Body)
+	var retry time.Duration
+
+	for {
+		ev, err := decoder.Next()
+		if err != nil {
+			if ctx.Err() != nil {
+				return retry, nil
+			}
+			return retry, err
+		}
+		if ev.Retry > 0 {
+			retry = ev.Retry
+		}
+		if ev.Data == "" {
+			continue
+		}
+
+		var raw rawEvent
+		if err := json.Unmarshal([]byte(ev.Data), &raw); err != nil {
+			select {
+			case s.errs <- err:
+			case <-s.done:
+				return retry, nil
+			}
+			continue
+		}
+
+		eventNumber := lastEventNumber + 1
+		fmt.Sscanf(ev.ID, "%d", &eventNumber)
+
+		event := &Event{
+			EventID:   raw.EventID,
+			EventType: raw.EventType,
+			Data:      raw.Data,
+			MetaData:  raw.MetaData,
+		}
+		select {
+		case s.events <- &EventResponse{Event: event, EventNumber: eventNumber}:
+			lastEventNumber = eventNumber
+			*resumeFrom = eventNumber
+		case <-s.done:
+			return retry, nil
+		}
+	}
+}